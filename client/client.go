@@ -0,0 +1,177 @@
+// Package client talks to a running goshare daemon over its HTTP/JSON API,
+// so the CLI can issue commands without constructing its own ipfs.Connector.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"goshare/result"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// ApiFile mirrors daemon.ApiFile; duplicated here to avoid the client
+// package depending on the daemon package.
+const ApiFile = ".goshare/api"
+
+// ApiAddr returns the base URL of a running daemon, and whether one is configured.
+func ApiAddr() (string, bool) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", false
+	}
+
+	data, err := os.ReadFile(filepath.Join(home, ApiFile))
+	if err != nil {
+		return "", false
+	}
+
+	return string(data), true
+}
+
+// AddCommand uploads a file to the daemon's /add endpoint.
+type AddCommand struct {
+	BaseURL  string
+	FilePath string
+}
+
+func (c *AddCommand) Execute(ctx context.Context) (interface{}, error) {
+	file, err := os.Open(c.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %s", err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filepath.Base(c.FilePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %s", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("failed to read file: %s", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build request: %s", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/add", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %s", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var addResult result.AddResult
+	if err := do(req, &addResult); err != nil {
+		return nil, err
+	}
+	return addResult, nil
+}
+
+// GetCommand downloads a cid from the daemon's /get endpoint.
+type GetCommand struct {
+	BaseURL    string
+	Cid        string
+	OutputPath string
+}
+
+func (c *GetCommand) Execute(ctx context.Context) (interface{}, error) {
+	// The cid/path goes in the query string, not the URL path: a value like
+	// "/ipns/foo" contains a slash that http.ServeMux would otherwise clean
+	// out of the path before the daemon's handler ever saw it.
+	reqURL := c.BaseURL + "/get?cid=" + url.QueryEscape(c.Cid)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %s", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach daemon: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("daemon responded with status %s", resp.Status)
+	}
+
+	out, err := os.Create(c.OutputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %s", c.OutputPath, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return result.GetResult{Cid: c.Cid, OutputPath: c.OutputPath}, nil
+}
+
+// PinCommand asks the daemon to pin a cid via its /pin endpoint.
+type PinCommand struct {
+	BaseURL string
+	Cid     string
+}
+
+func (c *PinCommand) Execute(ctx context.Context) (interface{}, error) {
+	body, err := json.Marshal(map[string]string{"cid": c.Cid})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %s", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/pin", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var pinResult result.PinResult
+	if err := do(req, &pinResult); err != nil {
+		return nil, err
+	}
+	return pinResult, nil
+}
+
+// IdCommand fetches the daemon's peer id via its /id endpoint.
+type IdCommand struct {
+	BaseURL string
+}
+
+func (c *IdCommand) Execute(ctx context.Context) (interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/id", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %s", err)
+	}
+
+	var idResult result.IdResult
+	if err := do(req, &idResult); err != nil {
+		return nil, err
+	}
+
+	return idResult, nil
+}
+
+func do(req *http.Request, out interface{}) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach daemon: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("daemon responded with status %s", resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,201 @@
+// Package daemon runs goshare as a long-lived process that owns an
+// ipfs.Connector and exposes it over an HTTP/JSON API, so repeated CLI
+// invocations don't each have to re-config a repo and re-bootstrap the DHT.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	logging "github.com/ipfs/go-log/v2"
+	"goshare/ipfs"
+	"goshare/metrics"
+	"goshare/result"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+var logger = logging.Logger("goshare-daemon")
+
+// ApiFile is the file the daemon writes its listen address to, relative to
+// the user's home directory, so the CLI knows where to find a running daemon.
+const ApiFile = ".goshare/api"
+
+// Daemon serves an ipfs.Connector over HTTP.
+type Daemon struct {
+	connector   *ipfs.Connector
+	addr        string
+	metricsAddr string
+}
+
+// New creates a Daemon that will listen on addr (e.g. "127.0.0.1:5001"). If
+// metricsAddr is non-empty, a Prometheus /metrics endpoint is also served on
+// that address.
+func New(connector *ipfs.Connector, addr string, metricsAddr string) *Daemon {
+	return &Daemon{connector: connector, addr: addr, metricsAddr: metricsAddr}
+}
+
+// ListenAndServe starts the HTTP API and blocks until ctx is cancelled or the
+// server fails. It writes the listen address to ApiFile on startup and
+// removes it on shutdown.
+func (d *Daemon) ListenAndServe(ctx context.Context) error {
+	if err := d.writeApiFile(); err != nil {
+		return err
+	}
+	defer d.removeApiFile()
+
+	if d.metricsAddr != "" {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", metrics.Handler())
+		metricsServer := &http.Server{Addr: d.metricsAddr, Handler: metricsMux}
+		go func() {
+			<-ctx.Done()
+			_ = metricsServer.Close()
+		}()
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Errorf("metrics server stopped: %s", err)
+			}
+		}()
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/add", d.handleAdd)
+	mux.HandleFunc("/get", d.handleGet)
+	mux.HandleFunc("/pin", d.handlePin)
+	mux.HandleFunc("/id", d.handleId)
+
+	server := &http.Server{Addr: d.addr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	err := server.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("daemon stopped: %s", err)
+	}
+	return nil
+}
+
+func (d *Daemon) writeApiFile() error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to locate home directory: %s", err)
+	}
+
+	path := filepath.Join(home, ApiFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %s", filepath.Dir(path), err)
+	}
+
+	return os.WriteFile(path, []byte("http://"+d.addr), 0644)
+}
+
+func (d *Daemon) removeApiFile() {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	_ = os.Remove(filepath.Join(home, ApiFile))
+}
+
+func (d *Daemon) handleAdd(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read uploaded file: %s", err), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	tmp, err := os.CreateTemp("", "goshare-add-*")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to buffer upload: %s", err), http.StatusInternalServerError)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, file); err != nil {
+		http.Error(w, fmt.Sprintf("failed to buffer upload: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	cid, err := d.connector.AddFile(r.Context(), tmp.Name(), false)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result.AddResult{Cid: cid})
+}
+
+func (d *Daemon) handleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The cid/path is carried as a query parameter rather than a path segment:
+	// an embedded "/" (e.g. "/ipns/foo") would otherwise be normalized away by
+	// ServeMux's path cleaning before the handler ever sees it.
+	contentId := r.URL.Query().Get("cid")
+	if contentId == "" {
+		http.Error(w, "missing cid", http.StatusBadRequest)
+		return
+	}
+
+	tmp, err := os.CreateTemp("", "goshare-get-*")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to buffer file: %s", err), http.StatusInternalServerError)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	tmp.Close()
+
+	if err := d.connector.GetFile(r.Context(), contentId, tmp.Name()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.ServeFile(w, r, tmp.Name())
+}
+
+func (d *Daemon) handlePin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Cid string `json:"cid"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := d.connector.Pin(r.Context(), body.Cid); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, result.PinResult{Cid: body.Cid})
+}
+
+func (d *Daemon) handleId(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, result.IdResult{PeerId: d.connector.Node.Identity.String()})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"goshare/result"
+	"strings"
+)
+
+// EncodingType selects how a command's result is rendered.
+type EncodingType string
+
+const (
+	EncodingText   EncodingType = "text"
+	EncodingJSON   EncodingType = "json"
+	EncodingNDJSON EncodingType = "ndjson"
+)
+
+// Marshaler renders a command result for a single encoding.
+type Marshaler func(v interface{}) ([]byte, error)
+
+// MarshalerMap mirrors go-ipfs's per-encoding marshaler table.
+var MarshalerMap = map[EncodingType]Marshaler{
+	EncodingText:   marshalText,
+	EncodingJSON:   marshalJSON,
+	EncodingNDJSON: marshalNDJSON,
+}
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func marshalNDJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// marshalText renders a result as human-readable text, one line per item.
+func marshalText(v interface{}) ([]byte, error) {
+	var lines []string
+
+	switch r := v.(type) {
+	case result.ConfigResult:
+		lines = append(lines, fmt.Sprintf("Configured repository %s", r.Repository))
+	case result.AddResult:
+		lines = append(lines, fmt.Sprintf("Added %s with Cid: %s", r.Name, r.Cid))
+		for _, f := range r.Files {
+			lines = append(lines, fmt.Sprintf("  %s -> %s", f.Path, f.Cid))
+		}
+	case result.GetResult:
+		lines = append(lines, fmt.Sprintf("Successfully wrote %s to %s", r.Cid, r.OutputPath))
+	case result.PinResult:
+		lines = append(lines, fmt.Sprintf("Pinned %s", r.Cid))
+	case result.UnpinResult:
+		lines = append(lines, fmt.Sprintf("Unpinned %s", r.Cid))
+	case result.ListPinsResult:
+		lines = append(lines, r.Cids...)
+	case result.PinStatusResult:
+		lines = append(lines, fmt.Sprintf("%s: %s", r.Cid, r.Status))
+	case result.RemoteConfigResult:
+		lines = append(lines, fmt.Sprintf("Configured remote pinning service %s", r.Name))
+	case result.PinRemoteResult:
+		lines = append(lines, fmt.Sprintf("Mirrored %s to %s, request id: %s", r.Cid, r.Service, r.RequestId))
+	case result.AnnounceResult:
+		lines = append(lines, fmt.Sprintf("Announced %s on %s", r.Cid, r.Topic))
+	case result.KeyGenResult:
+		lines = append(lines, fmt.Sprintf("Generated key %s: %s", r.Name, r.PeerId))
+	case result.ListKeysResult:
+		for _, k := range r.Keys {
+			lines = append(lines, fmt.Sprintf("%s: %s", k.Name, k.Id))
+		}
+	case result.PublishResult:
+		lines = append(lines, fmt.Sprintf("Published /ipns/%s -> %s", r.IpnsName, r.Cid))
+	case result.ResolveResult:
+		lines = append(lines, r.Path)
+	case result.IdResult:
+		lines = append(lines, r.PeerId)
+	default:
+		lines = append(lines, fmt.Sprintf("%+v", v))
+	}
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
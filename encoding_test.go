@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"goshare/result"
+)
+
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{
+			name: "pin",
+			v:    result.PinResult{Cid: "bafy123"},
+			want: "Pinned bafy123",
+		},
+		{
+			name: "pin status",
+			v:    result.PinStatusResult{Cid: "bafy123", Status: "recursive"},
+			want: "bafy123: recursive",
+		},
+		{
+			name: "list pins",
+			v:    result.ListPinsResult{Cids: []string{"bafy1", "bafy2"}},
+			want: "bafy1\nbafy2",
+		},
+		{
+			name: "add with files",
+			v: result.AddResult{
+				Cid:  "bafyRoot",
+				Name: "dir",
+				Files: []result.AddedFile{
+					{Path: "dir/a.txt", Cid: "bafyA"},
+				},
+			},
+			want: "Added dir with Cid: bafyRoot\n  dir/a.txt -> bafyA",
+		},
+		{
+			name: "unknown type falls back to %+v",
+			v:    struct{ X int }{X: 1},
+			want: "{X:1}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := marshalText(tt.v)
+			if err != nil {
+				t.Fatalf("marshalText returned error: %s", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("marshalText(%v) = %q, want %q", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	got, err := marshalJSON(result.PinResult{Cid: "bafy123"})
+	if err != nil {
+		t.Fatalf("marshalJSON returned error: %s", err)
+	}
+	if string(got) != `{"cid":"bafy123"}` {
+		t.Errorf("marshalJSON = %s, want %s", got, `{"cid":"bafy123"}`)
+	}
+}
+
+func TestMarshalNDJSONAppendsExactlyOneNewline(t *testing.T) {
+	got, err := marshalNDJSON(result.PinResult{Cid: "bafy123"})
+	if err != nil {
+		t.Fatalf("marshalNDJSON returned error: %s", err)
+	}
+	if !strings.HasSuffix(string(got), "\n") {
+		t.Fatalf("marshalNDJSON = %q, want trailing newline", got)
+	}
+	if strings.HasSuffix(string(got), "\n\n") {
+		t.Errorf("marshalNDJSON = %q, doubled the trailing newline", got)
+	}
+}
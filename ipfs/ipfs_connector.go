@@ -3,24 +3,31 @@ package ipfs
 import (
 	"context"
 	"fmt"
-	"github.com/fatih/color"
-	_ "github.com/fatih/color"
 	icore "github.com/ipfs/boxo/coreiface"
+	"github.com/ipfs/boxo/coreiface/options"
 	"github.com/ipfs/boxo/files"
 	"github.com/ipfs/boxo/path"
 	"github.com/ipfs/go-cid"
+	logging "github.com/ipfs/go-log/v2"
+	metricsScope "github.com/ipfs/go-metrics-interface"
 	"github.com/ipfs/kubo/config"
 	"github.com/ipfs/kubo/core"
 	"github.com/ipfs/kubo/core/coreapi"
 	"github.com/ipfs/kubo/core/node/libp2p"
 	"github.com/ipfs/kubo/plugin/loader"
 	"github.com/ipfs/kubo/repo/fsrepo"
+	"goshare/metrics"
+	"goshare/trace"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 )
 
+var logger = logging.Logger("goshare/ipfs")
+
 type Connector struct {
 	Api      icore.CoreAPI
 	Node     *core.IpfsNode
@@ -29,12 +36,24 @@ type Connector struct {
 
 var loadPluginsOnce sync.Once
 
-func loadAndInjectPlugins(externalPluginsPath string) error {
+// PluginPreloader is run against the PluginLoader between NewPluginLoader and
+// Initialize, letting library consumers inject custom plugins (e.g. a
+// bespoke datastore or content-routing implementation) without forking
+// goshare.
+type PluginPreloader func(*loader.PluginLoader) error
+
+func loadAndInjectPlugins(externalPluginsPath string, preloader PluginPreloader) error {
 	plugins, err := loader.NewPluginLoader(filepath.Join(externalPluginsPath, "plugins"))
 	if err != nil {
 		return fmt.Errorf("error loading plugins: %s", err)
 	}
 
+	if preloader != nil {
+		if err := preloader(plugins); err != nil {
+			return fmt.Errorf("error preloading plugins: %s", err)
+		}
+	}
+
 	// Load preloaded and external plugins
 	if err := plugins.Initialize(); err != nil {
 		return fmt.Errorf("error initializing plugins: %s", err)
@@ -47,10 +66,13 @@ func loadAndInjectPlugins(externalPluginsPath string) error {
 	return nil
 }
 
-func setupPlugins() error {
+// setupPlugins loads plugins exactly once per process. Only the preloader
+// passed on the first call is ever used, matching the once-per-process
+// lifetime of the plugin loader itself.
+func setupPlugins(preloader PluginPreloader) error {
 	var onceErr error
 	loadPluginsOnce.Do(func() {
-		onceErr = loadAndInjectPlugins("")
+		onceErr = loadAndInjectPlugins("", preloader)
 	})
 	if onceErr != nil {
 		return onceErr
@@ -79,7 +101,9 @@ func initRepository(repository string) error {
 	return nil
 }
 
-func buildNode(ctx context.Context, repository string) (*core.IpfsNode, error) {
+func buildNode(ctx context.Context, repository string, extraOpts map[string]bool, routing libp2p.RoutingOption) (*core.IpfsNode, error) {
+	ctx = metricsScope.CtxScope(ctx, "goshare")
+
 	repo, err := fsrepo.Open(repository)
 	if err != nil {
 		return nil, err
@@ -87,9 +111,10 @@ func buildNode(ctx context.Context, repository string) (*core.IpfsNode, error) {
 
 	// build the node
 	nodeOptions := &core.BuildCfg{
-		Online:  true,
-		Routing: libp2p.DHTOption,
-		Repo:    repo,
+		Online:    true,
+		Routing:   routing,
+		Repo:      repo,
+		ExtraOpts: extraOpts,
 	}
 
 	node, err := core.NewNode(ctx, nodeOptions)
@@ -97,18 +122,49 @@ func buildNode(ctx context.Context, repository string) (*core.IpfsNode, error) {
 	return node, err
 }
 
+// Options configures CreateNodeWithOptions.
+type Options struct {
+	// Repo is the filesystem path of the IPFS repo to open or initialize.
+	Repo string
+	// PluginPreloader, if set, is run against the PluginLoader before plugins
+	// are initialized.
+	PluginPreloader PluginPreloader
+	// ExtraOpts are passed through to core.BuildCfg.ExtraOpts. Defaults to
+	// enabling pubsub when nil.
+	ExtraOpts map[string]bool
+	// Routing is passed through to core.BuildCfg.Routing. Defaults to
+	// libp2p.DHTOption when nil.
+	Routing libp2p.RoutingOption
+}
+
 func CreateNode(ctx context.Context, repository string) (*Connector, error) {
-	err := setupPlugins()
+	return CreateNodeWithOptions(ctx, Options{Repo: repository})
+}
+
+// CreateNodeWithOptions builds a Connector the way CreateNode does, but lets
+// callers customize plugin loading, node build options, and routing.
+func CreateNodeWithOptions(ctx context.Context, opts Options) (*Connector, error) {
+	err := setupPlugins(opts.PluginPreloader)
 	if err != nil {
 		return nil, err
 	}
 
-	err = initRepository(repository)
+	err = initRepository(opts.Repo)
 	if err != nil {
 		return nil, err
 	}
 
-	node, err := buildNode(ctx, repository)
+	extraOpts := opts.ExtraOpts
+	if extraOpts == nil {
+		extraOpts = map[string]bool{"pubsub": true}
+	}
+
+	routing := opts.Routing
+	if routing == nil {
+		routing = libp2p.DHTOption
+	}
+
+	node, err := buildNode(ctx, opts.Repo, extraOpts, routing)
 	if err != nil {
 		return nil, err
 	}
@@ -118,16 +174,16 @@ func CreateNode(ctx context.Context, repository string) (*Connector, error) {
 		return nil, fmt.Errorf("failed to create ipfs api: %s", err)
 	}
 
-	return &Connector{Api: api, Node: node, RepoPath: repository}, err
+	return &Connector{Api: api, Node: node, RepoPath: opts.Repo}, err
 }
 
-func getFsFile(path string) (files.Node, error) {
+func getFsFile(path string, hidden bool) (files.Node, error) {
 	st, err := os.Stat(path)
 	if err != nil {
 		return nil, err
 	}
 
-	f, err := files.NewSerialFile(path, false, st)
+	f, err := files.NewSerialFile(path, hidden, st)
 	if err != nil {
 		return nil, err
 	}
@@ -135,34 +191,103 @@ func getFsFile(path string) (files.Node, error) {
 	return f, nil
 }
 
-func (c *Connector) AddFile(ctx context.Context, filePath string) error {
-	file, err := getFsFile(filePath)
+func (c *Connector) AddFile(ctx context.Context, filePath string, hidden bool) (string, error) {
+	start := time.Now()
+	defer func() { metrics.AddDuration.Observe(time.Since(start).Seconds()) }()
+
+	file, err := getFsFile(filePath, hidden)
 	if err != nil {
-		return fmt.Errorf("failed to load file: %s", err)
+		return "", fmt.Errorf("failed to load file: %s", err)
 	}
 
 	fileCid, err := c.Api.Unixfs().Add(ctx, file)
 	if err != nil {
-		return fmt.Errorf("failed to add file: %s", err)
+		return "", fmt.Errorf("failed to add file: %s", err)
 	}
 
-	color.Green("Added file with Cid: %s\n", fileCid.RootCid())
+	if st, err := os.Stat(filePath); err == nil {
+		metrics.AddBytes.Add(float64(st.Size()))
+	}
 
-	return err
+	cidStr := fileCid.RootCid().String()
+	if traceId, ok := trace.IDFromContext(ctx); ok {
+		logger.Infow("added file", "trace", traceId, "path", filePath, "cid", cidStr)
+	}
+
+	return cidStr, nil
 }
 
-func (c *Connector) GetFile(ctx context.Context, contentId string, outputPath string) error {
-	cidFile, _ := cid.Decode(contentId)
-	file, err := c.Api.Unixfs().Get(ctx, path.FromCid(cidFile))
+// AddPath adds filePath to IPFS, recursing into it if it is a directory and
+// reporting per-file progress as it goes. It returns the path -> cid pairs
+// that were added, in the order IPFS reported them.
+func (c *Connector) AddPath(ctx context.Context, filePath string, hidden bool, onProgress func(event *icore.AddEvent)) ([]icore.AddEvent, error) {
+	start := time.Now()
+	defer func() { metrics.AddDuration.Observe(time.Since(start).Seconds()) }()
+
+	file, err := getFsFile(filePath, hidden)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to load file: %s", err)
+	}
+
+	events := make(chan interface{})
+	var added []icore.AddEvent
+	// Progress events carry a file's cumulative byte count, so only the
+	// growth since the previous event for that file is added to the metric.
+	lastBytes := map[string]int64{}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for raw := range events {
+			event, ok := raw.(*icore.AddEvent)
+			if !ok {
+				continue
+			}
+			if onProgress != nil {
+				onProgress(event)
+			}
+			if event.Path.RootCid().Defined() {
+				added = append(added, *event)
+			} else {
+				metrics.AddBytes.Add(float64(event.Bytes - lastBytes[event.Name]))
+				lastBytes[event.Name] = event.Bytes
+			}
+		}
+	}()
+
+	_, err = c.Api.Unixfs().Add(ctx, file, options.Unixfs.Events(events), options.Unixfs.Progress(true))
+	close(events)
+	<-done
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s: %s", filePath, err)
+	}
+
+	return added, nil
+}
+
+func (c *Connector) GetFile(ctx context.Context, contentId string, outputPath string) error {
+	start := time.Now()
+	defer func() { metrics.GetDuration.Observe(time.Since(start).Seconds()) }()
+
+	var p path.Path
+	if strings.HasPrefix(contentId, "/ipns/") {
+		resolved, err := c.Api.Name().Resolve(ctx, contentId)
+		if err != nil {
+			return fmt.Errorf("failed to resolve ipns name: %s", err)
+		}
+		p = resolved
+	} else {
+		cidFile, err := cid.Decode(contentId)
+		if err != nil {
+			return fmt.Errorf("failed to decode cid: %s", err)
+		}
+		p = path.FromCid(cidFile)
 	}
 
-	err = files.WriteTo(file, outputPath)
+	file, err := c.Api.Unixfs().Get(ctx, p)
 	if err != nil {
 		return err
 	}
 
-	color.Green("Successfully Wrote file to %s", outputPath)
-	return err
+	return files.WriteTo(file, outputPath)
 }
@@ -0,0 +1,69 @@
+package ipfs
+
+import (
+	"context"
+	"fmt"
+	"github.com/ipfs/boxo/coreiface/options"
+	"github.com/ipfs/boxo/path"
+	"github.com/ipfs/go-cid"
+	"strings"
+)
+
+// KeyInfo describes a named IPNS key.
+type KeyInfo struct {
+	Name string
+	Id   string
+}
+
+// GenerateKey creates a new IPNS key named name and returns its peer id.
+func (c *Connector) GenerateKey(ctx context.Context, name string) (string, error) {
+	key, err := c.Api.Key().Generate(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key: %s", err)
+	}
+
+	return strings.TrimPrefix(key.Path().String(), "/ipns/"), nil
+}
+
+// ListKeys returns the IPNS keys available in this repo.
+func (c *Connector) ListKeys(ctx context.Context) ([]KeyInfo, error) {
+	keys, err := c.Api.Key().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %s", err)
+	}
+
+	infos := make([]KeyInfo, 0, len(keys))
+	for _, key := range keys {
+		infos = append(infos, KeyInfo{
+			Name: key.Name(),
+			Id:   strings.TrimPrefix(key.Path().String(), "/ipns/"),
+		})
+	}
+
+	return infos, nil
+}
+
+// Publish points the IPNS name for key at contentId so it always resolves to the latest version.
+func (c *Connector) Publish(ctx context.Context, key string, contentId string) (string, error) {
+	cidFile, err := cid.Decode(contentId)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode cid: %s", err)
+	}
+
+	entry, err := c.Api.Name().Publish(ctx, path.FromCid(cidFile), options.Name.Key(key))
+	if err != nil {
+		return "", fmt.Errorf("failed to publish ipns name: %s", err)
+	}
+
+	return entry.String(), nil
+}
+
+// Resolve resolves an /ipns/... name to the cid path it currently points at.
+func (c *Connector) Resolve(ctx context.Context, name string) (string, error) {
+	resolved, err := c.Api.Name().Resolve(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ipns name: %s", err)
+	}
+
+	return resolved.String(), nil
+}
@@ -0,0 +1,87 @@
+package ipfs
+
+import (
+	"context"
+	"fmt"
+	"github.com/ipfs/boxo/coreiface/options"
+	"github.com/ipfs/boxo/path"
+	"github.com/ipfs/go-cid"
+	"goshare/metrics"
+	"goshare/trace"
+)
+
+// Pin pins the content behind contentId so it is retained locally.
+func (c *Connector) Pin(ctx context.Context, contentId string) error {
+	cidFile, err := cid.Decode(contentId)
+	if err != nil {
+		return fmt.Errorf("failed to decode cid: %s", err)
+	}
+
+	err = c.Api.Pin().Add(ctx, path.FromCid(cidFile))
+	if err != nil {
+		return fmt.Errorf("failed to pin: %s", err)
+	}
+
+	metrics.PinCount.Inc()
+	if traceId, ok := trace.IDFromContext(ctx); ok {
+		logger.Infow("pinned", "trace", traceId, "cid", contentId)
+	}
+	return nil
+}
+
+// Unpin removes the local pin for contentId.
+func (c *Connector) Unpin(ctx context.Context, contentId string) error {
+	cidFile, err := cid.Decode(contentId)
+	if err != nil {
+		return fmt.Errorf("failed to decode cid: %s", err)
+	}
+
+	err = c.Api.Pin().Rm(ctx, path.FromCid(cidFile))
+	if err != nil {
+		return fmt.Errorf("failed to unpin: %s", err)
+	}
+
+	return nil
+}
+
+// ListPins returns the cids currently pinned in the local repo.
+func (c *Connector) ListPins(ctx context.Context) ([]string, error) {
+	pins, err := c.Api.Pin().Ls(ctx, options.Pin.Ls.All())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pins: %s", err)
+	}
+
+	var cids []string
+	for p := range pins {
+		if p.Err() != nil {
+			return nil, fmt.Errorf("failed to list pins: %s", p.Err())
+		}
+		cids = append(cids, p.Path().RootCid().String())
+	}
+
+	return cids, nil
+}
+
+// PinStatus reports whether contentId is currently pinned, and with what type.
+func (c *Connector) PinStatus(ctx context.Context, contentId string) (string, error) {
+	cidFile, err := cid.Decode(contentId)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode cid: %s", err)
+	}
+
+	pins, err := c.Api.Pin().Ls(ctx, options.Pin.Ls.All())
+	if err != nil {
+		return "", fmt.Errorf("failed to list pins: %s", err)
+	}
+
+	for p := range pins {
+		if p.Err() != nil {
+			return "", fmt.Errorf("failed to list pins: %s", p.Err())
+		}
+		if p.Path().RootCid().Equals(cidFile) {
+			return p.Type(), nil
+		}
+	}
+
+	return "not pinned", nil
+}
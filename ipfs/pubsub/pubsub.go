@@ -0,0 +1,121 @@
+// Package pubsub broadcasts and listens for file announcements over IPFS
+// pubsub topics, turning goshare from a blind CID-exchanger into a
+// collaborative share channel.
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ipfs/boxo/coreiface/options"
+	"github.com/ipfs/boxo/path"
+	"github.com/ipfs/go-cid"
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"goshare/ipfs"
+	"goshare/trace"
+	"time"
+)
+
+var logger = logging.Logger("goshare/pubsub")
+
+// Announcement is the JSON message broadcast on a topic when a peer announces a file.
+type Announcement struct {
+	Cid       string `json:"cid"`
+	Name      string `json:"name,omitempty"`
+	Size      uint64 `json:"size"`
+	Timestamp int64  `json:"timestamp"`
+	PeerId    string `json:"peer_id"`
+}
+
+// Announcer publishes and subscribes to file announcements for a Connector.
+type Announcer struct {
+	connector *ipfs.Connector
+}
+
+// NewAnnouncer wraps a Connector with pubsub-based announcement support.
+func NewAnnouncer(connector *ipfs.Connector) *Announcer {
+	return &Announcer{connector: connector}
+}
+
+// Publish broadcasts an Announcement on topic.
+func (a *Announcer) Publish(ctx context.Context, topic string, announcement Announcement) error {
+	data, err := json.Marshal(announcement)
+	if err != nil {
+		return fmt.Errorf("failed to encode announcement: %s", err)
+	}
+
+	if err := a.connector.Api.PubSub().Publish(ctx, topic, data); err != nil {
+		return fmt.Errorf("failed to publish announcement: %s", err)
+	}
+
+	return nil
+}
+
+// AnnounceFile builds an Announcement for contentId and publishes it on topic.
+func (a *Announcer) AnnounceFile(ctx context.Context, topic string, contentId string, name string) error {
+	cidFile, err := cid.Decode(contentId)
+	if err != nil {
+		return fmt.Errorf("failed to decode cid: %s", err)
+	}
+
+	stat, err := a.connector.Api.Object().Stat(ctx, path.FromCid(cidFile))
+	var size uint64
+	if err == nil {
+		size = uint64(stat.CumulativeSize)
+	}
+
+	if traceId, ok := trace.IDFromContext(ctx); ok {
+		logger.Infow("announcing file", "trace", traceId, "topic", topic, "cid", contentId)
+	}
+
+	return a.Publish(ctx, topic, Announcement{
+		Cid:       contentId,
+		Name:      name,
+		Size:      size,
+		Timestamp: time.Now().Unix(),
+		PeerId:    a.connector.Node.Identity.String(),
+	})
+}
+
+// Subscribe listens for announcements on topic until ctx is cancelled.
+func (a *Announcer) Subscribe(ctx context.Context, topic string) (<-chan Announcement, error) {
+	sub, err := a.connector.Api.PubSub().Subscribe(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %s", topic, err)
+	}
+
+	out := make(chan Announcement)
+	go func() {
+		defer close(out)
+		for {
+			msg, err := sub.Next(ctx)
+			if err != nil {
+				return
+			}
+
+			var announcement Announcement
+			if err := json.Unmarshal(msg.Data(), &announcement); err != nil {
+				continue
+			}
+
+			select {
+			case out <- announcement:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// ListPeers returns the peers currently subscribed to topic.
+func (a *Announcer) ListPeers(ctx context.Context, topic string) ([]peer.ID, error) {
+	peers, err := a.connector.Api.PubSub().Peers(ctx, options.PubSub.Topic(topic))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list peers for %s: %s", topic, err)
+	}
+
+	return peers, nil
+}
@@ -0,0 +1,107 @@
+package ipfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// RemotePinService is a client for a third-party pinning service implementing
+// the IPFS Pinning Services API (https://ipfs.github.io/pinning-services-api-spec/).
+type RemotePinService struct {
+	Name     string
+	Endpoint string
+	Token    string
+}
+
+// RemotePin is a single pin as tracked by a remote pinning service.
+type RemotePin struct {
+	RequestID string `json:"requestid"`
+	Status    string `json:"status"`
+	Created   string `json:"created"`
+	Pin       struct {
+		Cid  string `json:"cid"`
+		Name string `json:"name,omitempty"`
+	} `json:"pin"`
+}
+
+type remotePinListResponse struct {
+	Count   int         `json:"count"`
+	Results []RemotePin `json:"results"`
+}
+
+func (s *RemotePinService) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reqBody *bytes.Buffer
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %s", err)
+		}
+		reqBody = bytes.NewBuffer(encoded)
+	} else {
+		reqBody = bytes.NewBuffer(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.Endpoint+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach pinning service %s: %s", s.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("pinning service %s responded with status %s", s.Name, resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// AddPin asks the remote service to pin contentId under the given name.
+func (s *RemotePinService) AddPin(ctx context.Context, contentId string, name string) (*RemotePin, error) {
+	var pin RemotePin
+	err := s.do(ctx, http.MethodPost, "/pins", map[string]string{
+		"cid":  contentId,
+		"name": name,
+	}, &pin)
+	if err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+// GetPin fetches the status of a previously requested remote pin.
+func (s *RemotePinService) GetPin(ctx context.Context, requestId string) (*RemotePin, error) {
+	var pin RemotePin
+	err := s.do(ctx, http.MethodGet, "/pins/"+requestId, nil, &pin)
+	if err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+// RemovePin asks the remote service to drop the pin.
+func (s *RemotePinService) RemovePin(ctx context.Context, requestId string) error {
+	return s.do(ctx, http.MethodDelete, "/pins/"+requestId, nil, nil)
+}
+
+// ListPins lists the pins currently tracked by the remote service.
+func (s *RemotePinService) ListPins(ctx context.Context) ([]RemotePin, error) {
+	var list remotePinListResponse
+	err := s.do(ctx, http.MethodGet, "/pins", nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Results, nil
+}
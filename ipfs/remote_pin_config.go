@@ -0,0 +1,79 @@
+package ipfs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const remoteServicesFile = "remote_pin_services.json"
+
+// RemoteServiceConfig is the persisted configuration for a remote pinning service.
+type RemoteServiceConfig struct {
+	Endpoint string `json:"endpoint"`
+	Token    string `json:"token"`
+}
+
+func remoteServicesPath(repoPath string) string {
+	return filepath.Join(repoPath, remoteServicesFile)
+}
+
+// LoadRemoteServices reads the remote pinning services configured for this repo.
+func (c *Connector) LoadRemoteServices() (map[string]RemoteServiceConfig, error) {
+	data, err := os.ReadFile(remoteServicesPath(c.RepoPath))
+	if os.IsNotExist(err) {
+		return map[string]RemoteServiceConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read remote pin services: %s", err)
+	}
+
+	services := map[string]RemoteServiceConfig{}
+	if err := json.Unmarshal(data, &services); err != nil {
+		return nil, fmt.Errorf("failed to parse remote pin services: %s", err)
+	}
+
+	return services, nil
+}
+
+// SaveRemoteServices persists the remote pinning services configured for this repo.
+func (c *Connector) SaveRemoteServices(services map[string]RemoteServiceConfig) error {
+	data, err := json.MarshalIndent(services, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode remote pin services: %s", err)
+	}
+
+	if err := os.WriteFile(remoteServicesPath(c.RepoPath), data, 0600); err != nil {
+		return fmt.Errorf("failed to write remote pin services: %s", err)
+	}
+
+	return nil
+}
+
+// AddRemoteService registers a remote pinning service under name for later use by pin-remote.
+func (c *Connector) AddRemoteService(name, endpoint, token string) error {
+	services, err := c.LoadRemoteServices()
+	if err != nil {
+		return err
+	}
+
+	services[name] = RemoteServiceConfig{Endpoint: endpoint, Token: token}
+
+	return c.SaveRemoteServices(services)
+}
+
+// RemoteService looks up a configured remote pinning service by name.
+func (c *Connector) RemoteService(name string) (*RemotePinService, error) {
+	services, err := c.LoadRemoteServices()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, ok := services[name]
+	if !ok {
+		return nil, fmt.Errorf("no remote pinning service configured named %q", name)
+	}
+
+	return &RemotePinService{Name: name, Endpoint: cfg.Endpoint, Token: cfg.Token}, nil
+}
@@ -0,0 +1,77 @@
+package ipfs
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadRemoteServicesMissingFileReturnsEmptyMap(t *testing.T) {
+	c := &Connector{RepoPath: t.TempDir()}
+
+	services, err := c.LoadRemoteServices()
+	if err != nil {
+		t.Fatalf("LoadRemoteServices returned error: %s", err)
+	}
+	if len(services) != 0 {
+		t.Errorf("LoadRemoteServices = %v, want empty map", services)
+	}
+}
+
+func TestSaveThenLoadRemoteServicesRoundTrip(t *testing.T) {
+	c := &Connector{RepoPath: t.TempDir()}
+
+	want := map[string]RemoteServiceConfig{
+		"web3storage": {Endpoint: "https://api.web3.storage", Token: "secret"},
+	}
+	if err := c.SaveRemoteServices(want); err != nil {
+		t.Fatalf("SaveRemoteServices returned error: %s", err)
+	}
+
+	got, err := c.LoadRemoteServices()
+	if err != nil {
+		t.Fatalf("LoadRemoteServices returned error: %s", err)
+	}
+	if got["web3storage"] != want["web3storage"] {
+		t.Errorf("LoadRemoteServices = %v, want %v", got, want)
+	}
+}
+
+func TestSaveRemoteServicesWritesPrivateFile(t *testing.T) {
+	c := &Connector{RepoPath: t.TempDir()}
+
+	if err := c.SaveRemoteServices(map[string]RemoteServiceConfig{}); err != nil {
+		t.Fatalf("SaveRemoteServices returned error: %s", err)
+	}
+
+	info, err := os.Stat(remoteServicesPath(c.RepoPath))
+	if err != nil {
+		t.Fatalf("failed to stat remote services file: %s", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("remote services file mode = %o, want 0600", perm)
+	}
+}
+
+func TestAddRemoteServiceThenRemoteService(t *testing.T) {
+	c := &Connector{RepoPath: t.TempDir()}
+
+	if err := c.AddRemoteService("pinata", "https://api.pinata.cloud", "tok"); err != nil {
+		t.Fatalf("AddRemoteService returned error: %s", err)
+	}
+
+	svc, err := c.RemoteService("pinata")
+	if err != nil {
+		t.Fatalf("RemoteService returned error: %s", err)
+	}
+	if svc.Name != "pinata" || svc.Endpoint != "https://api.pinata.cloud" || svc.Token != "tok" {
+		t.Errorf("RemoteService = %+v, want name/endpoint/token from AddRemoteService", svc)
+	}
+}
+
+func TestRemoteServiceUnknownName(t *testing.T) {
+	c := &Connector{RepoPath: t.TempDir()}
+
+	if _, err := c.RemoteService("does-not-exist"); err == nil {
+		t.Error("RemoteService returned no error for an unconfigured service")
+	}
+}
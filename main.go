@@ -4,36 +4,114 @@ import (
 	"bufio"
 	"context"
 	"fmt"
-	"github.com/fatih/color"
+	icore "github.com/ipfs/boxo/coreiface"
+	logging "github.com/ipfs/go-log/v2"
+	"goshare/client"
+	"goshare/daemon"
 	"goshare/ipfs"
+	"goshare/ipfs/pubsub"
+	"goshare/result"
+	"goshare/trace"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
+var logger = logging.Logger("goshare")
+
+// autoGetDir is where IpfsSubscribeCommand's auto-get saves announced files,
+// relative to the user's home directory. Announcements come from untrusted
+// peers on the topic, so the announced name is never used as an output path
+// directly - it's rebased under this fixed directory instead.
+const autoGetDir = ".goshare/downloads"
+
+// autoGetPath turns an announcement's (untrusted) name into a safe output
+// path by discarding any directory components and joining what's left under
+// autoGetDir, so a peer can't use "../.." or an absolute path to make
+// auto-get overwrite arbitrary files on the subscriber's filesystem.
+func autoGetPath(name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %s", err)
+	}
+	base := filepath.Base(name)
+	if base == "" || base == "." || base == string(filepath.Separator) {
+		base = "unnamed"
+	}
+	dir := filepath.Join(home, autoGetDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %s", dir, err)
+	}
+	return filepath.Join(dir, base), nil
+}
+
 // IpfsConnector represents the connection to IPFS
 type IpfsConnector struct {
 	Connector *ipfs.Connector
 }
 
-// Command defines the interface for the command pattern
+// Command defines the interface for the command pattern. Execute returns a
+// typed result (see package result) that the Invoker renders according to
+// the configured encoding, rather than printing directly.
 type Command interface {
-	Execute(ctx context.Context) error
+	Execute(ctx context.Context) (interface{}, error)
 }
 
 // Invoker represents the client that triggers the command
 type Invoker struct {
-	command Command
+	command  Command
+	encoding EncodingType
 }
 
 func (i *Invoker) SetCommand(command Command) {
 	i.command = command
 }
 
-func (i *Invoker) ExecuteCommand(ctx context.Context) error {
+// SetEncoding sets the default encoding used to render results.
+func (i *Invoker) SetEncoding(encoding EncodingType) {
+	i.encoding = encoding
+}
+
+// ExecuteCommand runs the configured command and renders its result using
+// encoding, falling back to the Invoker's default encoding when empty.
+func (i *Invoker) ExecuteCommand(ctx context.Context, encoding EncodingType) error {
 	if i.command == nil {
 		return fmt.Errorf("command not set")
 	}
-	return i.command.Execute(ctx)
+
+	result, err := i.command.Execute(ctx)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+
+	if encoding == "" {
+		encoding = i.encoding
+	}
+	if encoding == "" {
+		encoding = EncodingText
+	}
+
+	marshal, ok := MarshalerMap[encoding]
+	if !ok {
+		return fmt.Errorf("unknown encoding: %s", encoding)
+	}
+
+	data, err := marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %s", err)
+	}
+	if len(data) > 0 {
+		if encoding == EncodingNDJSON {
+			fmt.Print(string(data))
+		} else {
+			fmt.Println(string(data))
+		}
+	}
+
+	return nil
 }
 
 // IpfsConfigCommand is a concrete command for configuring IPFS
@@ -42,26 +120,57 @@ type IpfsConfigCommand struct {
 	repository    string
 }
 
-func (c *IpfsConfigCommand) Execute(ctx context.Context) error {
+func (c *IpfsConfigCommand) Execute(ctx context.Context) (interface{}, error) {
 	connector, err := ipfs.CreateNode(ctx, c.repository)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	c.ipfsConnector.Connector = connector
-	return nil
+	return result.ConfigResult{Repository: c.repository}, nil
 }
 
 // IpfsAddCommand is a concrete command for adding a file to IPFS
 type IpfsAddCommand struct {
 	ipfsConnector *IpfsConnector
 	filePath      string
+	recursive     bool
+	hidden        bool
 }
 
-func (c *IpfsAddCommand) Execute(ctx context.Context) error {
+func (c *IpfsAddCommand) Execute(ctx context.Context) (interface{}, error) {
 	if c.ipfsConnector.Connector == nil {
-		return fmt.Errorf("run config command first")
+		return nil, fmt.Errorf("run config command first")
+	}
+
+	if !c.recursive {
+		cid, err := c.ipfsConnector.Connector.AddFile(ctx, c.filePath, c.hidden)
+		if err != nil {
+			return nil, err
+		}
+		return result.AddResult{Cid: cid, Name: c.filePath}, nil
+	}
+
+	added, err := c.ipfsConnector.Connector.AddPath(ctx, c.filePath, c.hidden, func(event *icore.AddEvent) {
+		fmt.Printf("  %s %d bytes\n", event.Name, event.Bytes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	addResult := result.AddResult{Name: c.filePath}
+	for _, event := range added {
+		cid := event.Path.RootCid().String()
+		addResult.Files = append(addResult.Files, result.AddedFile{Path: event.Name, Cid: cid})
 	}
-	return c.ipfsConnector.Connector.AddFile(ctx, c.filePath)
+	// AddPath emits the root of the tree last, same as go-ipfs's own add
+	// events - its Name is just the base directory (e.g. "somedir"), not the
+	// path the caller passed in, so it can't be matched against c.filePath.
+	// Take the last collected event instead of comparing names.
+	if len(addResult.Files) > 0 {
+		addResult.Cid = addResult.Files[len(addResult.Files)-1].Cid
+	}
+
+	return addResult, nil
 }
 
 // IpfsGetCommand is a concrete command for getting a file from IPFS
@@ -71,17 +180,300 @@ type IpfsGetCommand struct {
 	outputPath    string
 }
 
-func (c *IpfsGetCommand) Execute(ctx context.Context) error {
+func (c *IpfsGetCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	if err := c.ipfsConnector.Connector.GetFile(ctx, c.cid, c.outputPath); err != nil {
+		return nil, err
+	}
+	return result.GetResult{Cid: c.cid, OutputPath: c.outputPath}, nil
+}
+
+// IpfsPinCommand is a concrete command for pinning a cid locally
+type IpfsPinCommand struct {
+	ipfsConnector *IpfsConnector
+	cid           string
+}
+
+func (c *IpfsPinCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	if err := c.ipfsConnector.Connector.Pin(ctx, c.cid); err != nil {
+		return nil, err
+	}
+	return result.PinResult{Cid: c.cid}, nil
+}
+
+// IpfsUnpinCommand is a concrete command for unpinning a cid locally
+type IpfsUnpinCommand struct {
+	ipfsConnector *IpfsConnector
+	cid           string
+}
+
+func (c *IpfsUnpinCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	if err := c.ipfsConnector.Connector.Unpin(ctx, c.cid); err != nil {
+		return nil, err
+	}
+	return result.UnpinResult{Cid: c.cid}, nil
+}
+
+// IpfsListPinsCommand is a concrete command for listing locally pinned cids
+type IpfsListPinsCommand struct {
+	ipfsConnector *IpfsConnector
+}
+
+func (c *IpfsListPinsCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	cids, err := c.ipfsConnector.Connector.ListPins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result.ListPinsResult{Cids: cids}, nil
+}
+
+// IpfsPinStatusCommand is a concrete command for reporting whether a cid is pinned locally
+type IpfsPinStatusCommand struct {
+	ipfsConnector *IpfsConnector
+	cid           string
+}
+
+func (c *IpfsPinStatusCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	status, err := c.ipfsConnector.Connector.PinStatus(ctx, c.cid)
+	if err != nil {
+		return nil, err
+	}
+	return result.PinStatusResult{Cid: c.cid, Status: status}, nil
+}
+
+// IpfsRemoteConfigCommand is a concrete command for registering a remote pinning service
+type IpfsRemoteConfigCommand struct {
+	ipfsConnector *IpfsConnector
+	name          string
+	endpoint      string
+	token         string
+}
+
+func (c *IpfsRemoteConfigCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	if err := c.ipfsConnector.Connector.AddRemoteService(c.name, c.endpoint, c.token); err != nil {
+		return nil, err
+	}
+	return result.RemoteConfigResult{Name: c.name}, nil
+}
+
+// IpfsPinRemoteCommand is a concrete command for mirroring a cid to a remote pinning service
+type IpfsPinRemoteCommand struct {
+	ipfsConnector *IpfsConnector
+	service       string
+	cid           string
+	name          string
+}
+
+func (c *IpfsPinRemoteCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	service, err := c.ipfsConnector.Connector.RemoteService(c.service)
+	if err != nil {
+		return nil, err
+	}
+	pin, err := service.AddPin(ctx, c.cid, c.name)
+	if err != nil {
+		return nil, err
+	}
+	return result.PinRemoteResult{Cid: c.cid, Service: c.service, RequestId: pin.RequestID}, nil
+}
+
+// IpfsAnnounceCommand is a concrete command for broadcasting a file announcement on a pubsub topic
+type IpfsAnnounceCommand struct {
+	ipfsConnector *IpfsConnector
+	topic         string
+	cid           string
+	name          string
+}
+
+func (c *IpfsAnnounceCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	announcer := pubsub.NewAnnouncer(c.ipfsConnector.Connector)
+	if err := announcer.AnnounceFile(ctx, c.topic, c.cid, c.name); err != nil {
+		return nil, err
+	}
+	return result.AnnounceResult{Topic: c.topic, Cid: c.cid}, nil
+}
+
+// IpfsSubscribeCommand is a concrete command for listening to a pubsub topic for file announcements.
+// Announcements are a live stream rather than a single result, so this command
+// prints each one as it arrives instead of going through the Marshaler, and it
+// does so on a background goroutine so the REPL prompt comes straight back.
+type IpfsSubscribeCommand struct {
+	ipfsConnector *IpfsConnector
+	topic         string
+	autoGet       bool
+}
+
+func (c *IpfsSubscribeCommand) Execute(ctx context.Context) (interface{}, error) {
 	if c.ipfsConnector.Connector == nil {
-		return fmt.Errorf("run config command first")
+		return nil, fmt.Errorf("run config command first")
+	}
+	announcer := pubsub.NewAnnouncer(c.ipfsConnector.Connector)
+	announcements, err := announcer.Subscribe(ctx, c.topic)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Listening on %s in the background, run other commands or exit to stop\n", c.topic)
+	go func() {
+		for announcement := range announcements {
+			fmt.Printf("[%s] %s shared %s (%s, %d bytes)\n", c.topic, announcement.PeerId, announcement.Name, announcement.Cid, announcement.Size)
+			if c.autoGet {
+				outputPath, err := autoGetPath(announcement.Name)
+				if err != nil {
+					logger.Errorw("auto-get failed", "topic", c.topic, "cid", announcement.Cid, "error", err)
+					continue
+				}
+				if err := c.ipfsConnector.Connector.GetFile(ctx, announcement.Cid, outputPath); err != nil {
+					logger.Errorw("auto-get failed", "topic", c.topic, "cid", announcement.Cid, "error", err)
+				}
+			}
+		}
+	}()
+
+	return nil, nil
+}
+
+// IpfsKeyGenCommand is a concrete command for generating a new IPNS key
+type IpfsKeyGenCommand struct {
+	ipfsConnector *IpfsConnector
+	name          string
+}
+
+func (c *IpfsKeyGenCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	peerId, err := c.ipfsConnector.Connector.GenerateKey(ctx, c.name)
+	if err != nil {
+		return nil, err
+	}
+	return result.KeyGenResult{Name: c.name, PeerId: peerId}, nil
+}
+
+// IpfsListKeysCommand is a concrete command for listing IPNS keys
+type IpfsListKeysCommand struct {
+	ipfsConnector *IpfsConnector
+}
+
+func (c *IpfsListKeysCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	keys, err := c.ipfsConnector.Connector.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keyResults := make([]result.KeyInfo, 0, len(keys))
+	for _, key := range keys {
+		keyResults = append(keyResults, result.KeyInfo{Name: key.Name, Id: key.Id})
+	}
+	return result.ListKeysResult{Keys: keyResults}, nil
+}
+
+// IpfsPublishCommand is a concrete command for publishing a cid under an IPNS key
+type IpfsPublishCommand struct {
+	ipfsConnector *IpfsConnector
+	key           string
+	cid           string
+}
+
+func (c *IpfsPublishCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	ipnsName, err := c.ipfsConnector.Connector.Publish(ctx, c.key, c.cid)
+	if err != nil {
+		return nil, err
+	}
+	return result.PublishResult{IpnsName: ipnsName, Cid: c.cid}, nil
+}
+
+// IpfsResolveCommand is a concrete command for resolving an IPNS name to a cid path
+type IpfsResolveCommand struct {
+	ipfsConnector *IpfsConnector
+	name          string
+}
+
+func (c *IpfsResolveCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	resolved, err := c.ipfsConnector.Connector.Resolve(ctx, c.name)
+	if err != nil {
+		return nil, err
+	}
+	return result.ResolveResult{Path: resolved}, nil
+}
+
+// IpfsIdCommand is a concrete command for printing this node's peer id
+type IpfsIdCommand struct {
+	ipfsConnector *IpfsConnector
+}
+
+func (c *IpfsIdCommand) Execute(ctx context.Context) (interface{}, error) {
+	if c.ipfsConnector.Connector == nil {
+		return nil, fmt.Errorf("run config command first")
+	}
+	return result.IdResult{PeerId: c.ipfsConnector.Connector.Node.Identity.String()}, nil
+}
+
+// buildDaemonCommand builds the client.Command for an ipfsCommand line that a running daemon should serve.
+func buildDaemonCommand(baseURL string, ipfsCommand []string) (Command, error) {
+	switch ipfsCommand[0] {
+	case "add":
+		if ipfsCommand[1] == "-r" {
+			return nil, fmt.Errorf("recursive add is not supported via daemon")
+		}
+		if ipfsCommand[len(ipfsCommand)-1] == "--hidden" {
+			return nil, fmt.Errorf("hidden-file filtering is not supported via daemon")
+		}
+		return &client.AddCommand{BaseURL: baseURL, FilePath: ipfsCommand[1]}, nil
+	case "get":
+		return &client.GetCommand{BaseURL: baseURL, Cid: ipfsCommand[1], OutputPath: ipfsCommand[2]}, nil
+	case "pin":
+		return &client.PinCommand{BaseURL: baseURL, Cid: ipfsCommand[1]}, nil
+	case "id":
+		return &client.IdCommand{BaseURL: baseURL}, nil
+	default:
+		return nil, fmt.Errorf("command %q cannot run on a daemon", ipfsCommand[0])
 	}
-	return c.ipfsConnector.Connector.GetFile(ctx, c.cid, c.outputPath)
 }
 
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if len(os.Args) > 1 && os.Args[1] == "daemon" {
+		if err := runDaemon(ctx, os.Args[2:]); err != nil {
+			logger.Errorf("daemon exited: %s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	ipfsConnector := &IpfsConnector{}
 	invoker := &Invoker{}
 
@@ -96,23 +488,117 @@ func main() {
 		case "help":
 			fmt.Println("Available commands:")
 			fmt.Println(" - config <repository>")
-			fmt.Println(" - add <file-path>")
+			fmt.Println(" - add <file-path> [--hidden]")
+			fmt.Println(" - add -r <directory-path> [--hidden]")
 			fmt.Println(" - get <cid> <output-path>")
+			fmt.Println(" - pin <cid>")
+			fmt.Println(" - unpin <cid>")
+			fmt.Println(" - pins")
+			fmt.Println(" - remote-config <service> <endpoint> <token>")
+			fmt.Println(" - pin-remote <service> <cid> [name]")
+			fmt.Println(" - announce <topic> <cid> [name]")
+			fmt.Println(" - subscribe <topic> [auto-get]")
+			fmt.Println(" - key-gen <name>")
+			fmt.Println(" - keys")
+			fmt.Println(" - publish <key> <cid>")
+			fmt.Println(" - resolve <ipns-name>")
+			fmt.Println(" - id")
+			fmt.Println(" - set enc <text|json|ndjson>")
+			fmt.Println(" - any command may end with --enc=<text|json|ndjson>")
 			fmt.Println(" - exit")
 		case "exit":
 			fmt.Println("Exiting the app. Goodbye!")
 			os.Exit(0)
 		default:
-			err := handleIpfsCommand(command, ctx, ipfsConnector, invoker)
+			traceId := trace.NewID()
+			cmdCtx := trace.WithID(ctx, traceId)
+			logger.Infow("executing command", "trace", traceId, "command", command)
+
+			err := handleIpfsCommand(command, cmdCtx, ipfsConnector, invoker)
 			if err != nil {
-				color.Red(err.Error())
+				logger.Errorw("command failed", "trace", traceId, "command", command, "error", err)
 			}
 		}
 	}
 }
 
+// runDaemon boots an ipfs.Connector and serves it over HTTP until ctx is cancelled.
+func runDaemon(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: goshare daemon <repository> [addr] [metrics-addr]")
+	}
+
+	repository := args[0]
+	addr := "127.0.0.1:5001"
+	if len(args) > 1 {
+		addr = args[1]
+	}
+	metricsAddr := ""
+	if len(args) > 2 {
+		metricsAddr = args[2]
+	}
+
+	connector, err := ipfs.CreateNode(ctx, repository)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("goshare daemon listening on %s", addr)
+	if metricsAddr != "" {
+		logger.Infof("goshare metrics listening on %s", metricsAddr)
+	}
+	return daemon.New(connector, addr, metricsAddr).ListenAndServe(ctx)
+}
+
+// commandShouldRunOnDaemon reports whether a running daemon should serve this
+// command instead of the CLI constructing its own ipfs.Connector, following
+// the same pattern go-ipfs uses to decide when a command needs a daemon.
+func commandShouldRunOnDaemon(name string) bool {
+	switch name {
+	case "add", "get", "pin", "id":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseEncodingFlag pulls a trailing --enc=<encoding> flag out of fields, if present.
+func parseEncodingFlag(fields []string) ([]string, EncodingType) {
+	if len(fields) == 0 {
+		return fields, ""
+	}
+
+	last := fields[len(fields)-1]
+	if !strings.HasPrefix(last, "--enc=") {
+		return fields, ""
+	}
+
+	return fields[:len(fields)-1], EncodingType(strings.TrimPrefix(last, "--enc="))
+}
+
 func handleIpfsCommand(command string, ctx context.Context, ipfsConnector *IpfsConnector, invoker *Invoker) error {
-	ipfsCommand := strings.Fields(command)
+	ipfsCommand, encoding := parseEncodingFlag(strings.Fields(command))
+	if len(ipfsCommand) == 0 {
+		return nil
+	}
+
+	if ipfsCommand[0] == "set" {
+		if len(ipfsCommand) < 3 || ipfsCommand[1] != "enc" {
+			return fmt.Errorf("usage: set enc <text|json|ndjson>")
+		}
+		invoker.SetEncoding(EncodingType(ipfsCommand[2]))
+		return nil
+	}
+
+	if baseURL, ok := client.ApiAddr(); ok && commandShouldRunOnDaemon(ipfsCommand[0]) {
+		daemonCommand, err := buildDaemonCommand(baseURL, ipfsCommand)
+		if err != nil {
+			return err
+		}
+		invoker.SetCommand(daemonCommand)
+		return invoker.ExecuteCommand(ctx, encoding)
+	}
+
 	switch ipfsCommand[0] {
 	case "config":
 		configCommand := &IpfsConfigCommand{
@@ -124,9 +610,18 @@ func handleIpfsCommand(command string, ctx context.Context, ipfsConnector *IpfsC
 		if ipfsConnector.Connector == nil {
 			return fmt.Errorf("run config command first")
 		}
-		addCommand := &IpfsAddCommand{
-			ipfsConnector: ipfsConnector,
-			filePath:      ipfsCommand[1],
+		addCommand := &IpfsAddCommand{ipfsConnector: ipfsConnector}
+		if ipfsCommand[1] == "-r" {
+			addCommand.recursive = true
+			addCommand.filePath = ipfsCommand[2]
+			if len(ipfsCommand) > 3 && ipfsCommand[3] == "--hidden" {
+				addCommand.hidden = true
+			}
+		} else {
+			addCommand.filePath = ipfsCommand[1]
+			if len(ipfsCommand) > 2 && ipfsCommand[2] == "--hidden" {
+				addCommand.hidden = true
+			}
 		}
 		invoker.SetCommand(addCommand)
 	case "get":
@@ -139,10 +634,129 @@ func handleIpfsCommand(command string, ctx context.Context, ipfsConnector *IpfsC
 			outputPath:    ipfsCommand[2],
 		}
 		invoker.SetCommand(getCommand)
+	case "pin":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		pinCommand := &IpfsPinCommand{
+			ipfsConnector: ipfsConnector,
+			cid:           ipfsCommand[1],
+		}
+		invoker.SetCommand(pinCommand)
+	case "unpin":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		unpinCommand := &IpfsUnpinCommand{
+			ipfsConnector: ipfsConnector,
+			cid:           ipfsCommand[1],
+		}
+		invoker.SetCommand(unpinCommand)
+	case "pins":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		invoker.SetCommand(&IpfsListPinsCommand{ipfsConnector: ipfsConnector})
+	case "pin-status":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		pinStatusCommand := &IpfsPinStatusCommand{
+			ipfsConnector: ipfsConnector,
+			cid:           ipfsCommand[1],
+		}
+		invoker.SetCommand(pinStatusCommand)
+	case "remote-config":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		remoteConfigCommand := &IpfsRemoteConfigCommand{
+			ipfsConnector: ipfsConnector,
+			name:          ipfsCommand[1],
+			endpoint:      ipfsCommand[2],
+			token:         ipfsCommand[3],
+		}
+		invoker.SetCommand(remoteConfigCommand)
+	case "pin-remote":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		pinRemoteCommand := &IpfsPinRemoteCommand{
+			ipfsConnector: ipfsConnector,
+			service:       ipfsCommand[1],
+			cid:           ipfsCommand[2],
+		}
+		if len(ipfsCommand) > 3 {
+			pinRemoteCommand.name = ipfsCommand[3]
+		}
+		invoker.SetCommand(pinRemoteCommand)
+	case "announce":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		announceCommand := &IpfsAnnounceCommand{
+			ipfsConnector: ipfsConnector,
+			topic:         ipfsCommand[1],
+			cid:           ipfsCommand[2],
+		}
+		if len(ipfsCommand) > 3 {
+			announceCommand.name = ipfsCommand[3]
+		}
+		invoker.SetCommand(announceCommand)
+	case "subscribe":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		subscribeCommand := &IpfsSubscribeCommand{
+			ipfsConnector: ipfsConnector,
+			topic:         ipfsCommand[1],
+		}
+		if len(ipfsCommand) > 2 && ipfsCommand[2] == "auto-get" {
+			subscribeCommand.autoGet = true
+		}
+		invoker.SetCommand(subscribeCommand)
+	case "key-gen":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		keyGenCommand := &IpfsKeyGenCommand{
+			ipfsConnector: ipfsConnector,
+			name:          ipfsCommand[1],
+		}
+		invoker.SetCommand(keyGenCommand)
+	case "keys":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		invoker.SetCommand(&IpfsListKeysCommand{ipfsConnector: ipfsConnector})
+	case "publish":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		publishCommand := &IpfsPublishCommand{
+			ipfsConnector: ipfsConnector,
+			key:           ipfsCommand[1],
+			cid:           ipfsCommand[2],
+		}
+		invoker.SetCommand(publishCommand)
+	case "resolve":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		resolveCommand := &IpfsResolveCommand{
+			ipfsConnector: ipfsConnector,
+			name:          ipfsCommand[1],
+		}
+		invoker.SetCommand(resolveCommand)
+	case "id":
+		if ipfsConnector.Connector == nil {
+			return fmt.Errorf("run config command first")
+		}
+		invoker.SetCommand(&IpfsIdCommand{ipfsConnector: ipfsConnector})
 	default:
 		fmt.Printf("invalid command: %s\n", command)
 		return nil
 	}
 
-	return invoker.ExecuteCommand(ctx)
+	return invoker.ExecuteCommand(ctx, encoding)
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAutoGetPathSanitizesAnnouncedName(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{"/etc/passwd", "passwd"},
+		{"../../../root/.ssh/authorized_keys", "authorized_keys"},
+		{".", "unnamed"},
+		{"", "unnamed"},
+	}
+
+	for _, tt := range tests {
+		got, err := autoGetPath(tt.name)
+		if err != nil {
+			t.Fatalf("autoGetPath(%q) returned error: %s", tt.name, err)
+		}
+
+		wantDir := filepath.Join(home, autoGetDir)
+		if !strings.HasPrefix(got, wantDir+string(filepath.Separator)) {
+			t.Fatalf("autoGetPath(%q) = %q, want path under %q", tt.name, got, wantDir)
+		}
+		if filepath.Base(got) != tt.want {
+			t.Errorf("autoGetPath(%q) = %q, want base %q", tt.name, got, tt.want)
+		}
+	}
+}
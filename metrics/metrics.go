@@ -0,0 +1,44 @@
+// Package metrics exposes goshare's per-operation counters and histograms as
+// Prometheus metrics, and a handler to serve them.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+var (
+	// AddBytes tracks the total number of bytes added to IPFS.
+	AddBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "goshare_add_bytes_total",
+		Help: "Total bytes added to IPFS via goshare.add.",
+	})
+
+	// AddDuration tracks how long add operations take.
+	AddDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "goshare_add_duration_seconds",
+		Help: "Duration of goshare.add operations.",
+	})
+
+	// GetDuration tracks how long get operations take.
+	GetDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "goshare_get_duration_seconds",
+		Help: "Duration of goshare.get operations.",
+	})
+
+	// PinCount tracks the number of successful pin operations.
+	PinCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "goshare_pin_count_total",
+		Help: "Total number of goshare.pin operations.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(AddBytes, AddDuration, GetDuration, PinCount)
+}
+
+// Handler serves the registered metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,100 @@
+// Package result holds the typed values commands return, so both the CLI's
+// Marshaler and the daemon's HTTP handlers can render them without either
+// side depending on the other.
+package result
+
+// ConfigResult is returned by the config command.
+type ConfigResult struct {
+	Repository string `json:"repository"`
+}
+
+// AddedFile is a single path -> cid pair produced by a recursive add.
+type AddedFile struct {
+	Path string `json:"path"`
+	Cid  string `json:"cid"`
+}
+
+// AddResult is returned by the add command.
+type AddResult struct {
+	Cid   string      `json:"cid"`
+	Name  string      `json:"name"`
+	Files []AddedFile `json:"files,omitempty"`
+}
+
+// GetResult is returned by the get command.
+type GetResult struct {
+	Cid        string `json:"cid"`
+	OutputPath string `json:"output_path"`
+}
+
+// PinResult is returned by the pin command.
+type PinResult struct {
+	Cid string `json:"cid"`
+}
+
+// UnpinResult is returned by the unpin command.
+type UnpinResult struct {
+	Cid string `json:"cid"`
+}
+
+// ListPinsResult is returned by the pins command.
+type ListPinsResult struct {
+	Cids []string `json:"cids"`
+}
+
+// PinStatusResult is returned by the pin-status command.
+type PinStatusResult struct {
+	Cid    string `json:"cid"`
+	Status string `json:"status"`
+}
+
+// RemoteConfigResult is returned by the remote-config command.
+type RemoteConfigResult struct {
+	Name string `json:"name"`
+}
+
+// PinRemoteResult is returned by the pin-remote command.
+type PinRemoteResult struct {
+	Cid       string `json:"cid"`
+	Service   string `json:"service"`
+	RequestId string `json:"request_id"`
+}
+
+// AnnounceResult is returned by the announce command.
+type AnnounceResult struct {
+	Topic string `json:"topic"`
+	Cid   string `json:"cid"`
+}
+
+// KeyGenResult is returned by the key-gen command.
+type KeyGenResult struct {
+	Name   string `json:"name"`
+	PeerId string `json:"peer_id"`
+}
+
+// KeyInfo describes a single IPNS key.
+type KeyInfo struct {
+	Name string `json:"name"`
+	Id   string `json:"id"`
+}
+
+// ListKeysResult is returned by the keys command.
+type ListKeysResult struct {
+	Keys []KeyInfo `json:"keys"`
+}
+
+// PublishResult is returned by the publish command.
+type PublishResult struct {
+	IpnsName string `json:"ipns_name"`
+	Cid      string `json:"cid"`
+}
+
+// ResolveResult is returned by the resolve command.
+type ResolveResult struct {
+	Path string `json:"path"`
+}
+
+// IdResult is returned by the id command.
+type IdResult struct {
+	PeerId string `json:"peer_id"`
+}
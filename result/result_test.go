@@ -0,0 +1,33 @@
+package result
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAddResultOmitsEmptyFiles(t *testing.T) {
+	data, err := json.Marshal(AddResult{Cid: "bafy123", Name: "file.txt"})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %s", err)
+	}
+
+	want := `{"cid":"bafy123","name":"file.txt"}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s (files should be omitted when empty)", data, want)
+	}
+}
+
+func TestPublishResultJSONTags(t *testing.T) {
+	data, err := json.Marshal(PublishResult{IpnsName: "k51q...", Cid: "bafy123"})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %s", err)
+	}
+
+	var round map[string]string
+	if err := json.Unmarshal(data, &round); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+	if round["ipns_name"] != "k51q..." || round["cid"] != "bafy123" {
+		t.Errorf("Marshal = %s, want ipns_name/cid keys", data)
+	}
+}
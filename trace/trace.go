@@ -0,0 +1,33 @@
+// Package trace attaches a short-lived trace ID to a context.Context so that
+// logs emitted by different subsystems (ipfs, pubsub, daemon, ...) while
+// servicing a single REPL command can be correlated, even though each
+// subsystem logs independently.
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type key struct{}
+
+// NewID returns a new random trace ID.
+func NewID() string {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// WithID returns a copy of ctx carrying id, retrievable with IDFromContext.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
+
+// IDFromContext returns the trace ID carried by ctx, if any.
+func IDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(key{}).(string)
+	return id, ok
+}
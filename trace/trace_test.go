@@ -0,0 +1,36 @@
+package trace
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewIDIsUniqueAndHexEncoded(t *testing.T) {
+	a := NewID()
+	b := NewID()
+
+	if a == b {
+		t.Fatalf("NewID returned the same id twice: %q", a)
+	}
+	if len(a) != 8 {
+		t.Errorf("NewID() = %q, want 8 hex characters", a)
+	}
+}
+
+func TestWithIDRoundTrip(t *testing.T) {
+	ctx := WithID(context.Background(), "deadbeef")
+
+	id, ok := IDFromContext(ctx)
+	if !ok {
+		t.Fatal("IDFromContext returned ok=false for a context carrying an id")
+	}
+	if id != "deadbeef" {
+		t.Errorf("IDFromContext = %q, want %q", id, "deadbeef")
+	}
+}
+
+func TestIDFromContextMissing(t *testing.T) {
+	if _, ok := IDFromContext(context.Background()); ok {
+		t.Error("IDFromContext returned ok=true for a context with no id")
+	}
+}